@@ -0,0 +1,208 @@
+package emitter
+
+import (
+	"context"
+	"sync"
+)
+
+// OnContext works like On but automatically calls Off for listener, closing
+// its channel, once ctx is done.
+func (e *Emitter) OnContext(ctx context.Context, topic string, listener Listener, middlewares ...func(Event)) {
+	e.On(topic, listener, middlewares...)
+	go func() {
+		<-ctx.Done()
+		e.Off(topic, listener)
+	}()
+}
+
+// EmitContext works like Emit, including honoring WithOrderedDispatch, but
+// aborts pending sends to listeners as soon as ctx is cancelled, instead of
+// blocking on a slow listener with FlagSkip unset until it drains.
+func (e *Emitter) EmitContext(ctx context.Context, event Event) chan struct{} {
+	done := make(chan struct{}, 1)
+
+	e.runInterceptors(event)
+
+	match, _ := e.matched(event.Topic())
+
+	var wg sync.WaitGroup
+	var haveToWait bool
+	for _, _topic := range match {
+		applyMiddlewares(event, e.getMiddlewares(_topic))
+		e.listMans.Range(func(topicRaw, smRaw interface{}) bool {
+			if topicRaw.(string) != _topic {
+				return true
+			}
+			smRaw.(*sync.Map).Range(func(_, lRaw interface{}) bool {
+				listMan := lRaw.(*listenerManager)
+				evn := event.Clone()
+				applyMiddlewares(evn, listMan.middlewares)
+				if (evn.Flag() | FlagVoid) == evn.Flag() {
+					return true
+				}
+				if e.ordered {
+					_, remove, _ := pushEventContext(ctx, done, listMan.ch, evn)
+					if remove {
+						e.Off(event.Topic(), listMan.listener)
+					}
+					return true
+				}
+				wg.Add(1)
+				haveToWait = true
+				go func(lm *listenerManager, event Event) {
+					defer wg.Done()
+					_, remove, _ := pushEventContext(ctx, done, lm.ch, event)
+					if remove {
+						e.Off(event.Topic(), lm.listener)
+					}
+				}(listMan, evn)
+				return true
+			})
+			return true
+		})
+	}
+
+	e.matcherSubs.Range(func(_, subRaw interface{}) bool {
+		sub := subRaw.(*matcherSubscription)
+		if !sub.matcher.Match(event.Topic(), event) {
+			return true
+		}
+		evn := event.Clone()
+		applyMiddlewares(evn, e.getMatcherMiddlewares(sub.matcher))
+		applyMiddlewares(evn, sub.lm.middlewares)
+		if (evn.Flag() | FlagVoid) == evn.Flag() {
+			return true
+		}
+		if e.ordered {
+			_, remove, _ := pushEventContext(ctx, done, sub.lm.ch, evn)
+			if remove {
+				e.OffMatch(sub.matcher, sub.lm.listener)
+			}
+			return true
+		}
+		wg.Add(1)
+		haveToWait = true
+		go func(lm *listenerManager, matcher Matcher, event Event) {
+			defer wg.Done()
+			_, remove, _ := pushEventContext(ctx, done, lm.ch, event)
+			if remove {
+				e.OffMatch(matcher, lm.listener)
+			}
+		}(sub.lm, sub.matcher, evn)
+		return true
+	})
+
+	if haveToWait {
+		go func(done chan struct{}) {
+			wg.Wait()
+			close(done)
+		}(done)
+	} else {
+		close(done)
+	}
+	return done
+}
+
+func pushEventContext(
+	ctx context.Context,
+	done chan struct{},
+	lstnr chan Event,
+	event Event,
+) (success, remove bool, err error) {
+	isOnce := (event.Flag() | FlagOnce) == event.Flag()
+	isSkip := (event.Flag() | FlagSkip) == event.Flag()
+
+	sent, canceled := sendContext(
+		ctx,
+		done,
+		lstnr,
+		event,
+		!isSkip,
+	)
+	success = sent
+
+	if !sent && !canceled {
+		remove = false
+	} else if !canceled {
+		remove = isOnce
+	}
+	return
+}
+
+func sendContext(
+	ctx context.Context,
+	done chan struct{},
+	ch chan Event,
+	e Event, wait bool,
+) (sent, canceled bool) {
+
+	defer func() {
+		if r := recover(); r != nil {
+			canceled = false
+			sent = false
+		}
+	}()
+
+	if !wait {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		case ch <- e:
+			sent = true
+			return
+		default:
+			return
+		}
+
+	} else {
+		select {
+		case <-ctx.Done():
+		case <-done:
+		case ch <- e:
+			sent = true
+			return
+		}
+
+	}
+	canceled = true
+	return
+}
+
+// Close unsubscribes every listener across every topic and every OnMatch
+// subscription, draining each listenerManager's goroutine, and returns
+// once they have all stopped or ctx expires, whichever happens first.
+func (e *Emitter) Close(ctx context.Context) error {
+	var stopped []chan struct{}
+	e.listMans.Range(func(topicRaw, smRaw interface{}) bool {
+		smRaw.(*sync.Map).Range(func(_, lRaw interface{}) bool {
+			lm := lRaw.(*listenerManager)
+			stopped = append(stopped, lm.stopped)
+			close(lm.ch)
+			return true
+		})
+		e.listMans.Delete(topicRaw)
+		return true
+	})
+	e.matcherSubs.Range(func(key, subRaw interface{}) bool {
+		sub := subRaw.(*matcherSubscription)
+		stopped = append(stopped, sub.lm.stopped)
+		close(sub.lm.ch)
+		e.matcherSubs.Delete(key)
+		return true
+	})
+
+	done := make(chan struct{})
+	go func() {
+		for _, s := range stopped {
+			<-s
+		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,100 @@
+package emitter
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestEmitContext(t *testing.T) {
+	ee := New(0)
+	done := make(chan struct{}, 1)
+	ee.On("test", ListenerFunc(func(event Event) {
+		expect(t, event.Subject(), "elem")
+		done <- struct{}{}
+	}))
+	<-ee.EmitContext(context.Background(), NewEvent("test", "elem"))
+	<-done
+}
+
+func TestEmitContextCancelled(t *testing.T) {
+	ee := New(0)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// the context is already cancelled, so EmitContext must return whether
+	// or not the listener's goroutine takes the event first.
+	ee.On("test", ListenerFunc(func(event Event) {}))
+	<-ee.EmitContext(ctx, NewEvent("test", "elem"))
+}
+
+func TestEmitContextDeliversToMatcherSubscriptions(t *testing.T) {
+	ee := New(0)
+	qm, err := NewQueryMatcher(`Type='block'`, nil)
+	expect(t, err, nil)
+
+	done := make(chan struct{}, 1)
+	ee.OnMatch(qm, ListenerFunc(func(e Event) {
+		done <- struct{}{}
+	}))
+
+	<-ee.EmitContext(context.Background(), NewEvent("chain", blockEvent{Type: "block"}))
+	<-done
+}
+
+func TestEmitterCloseDrainsMatcherSubscriptions(t *testing.T) {
+	ee := New(1)
+	qm, err := NewQueryMatcher(`Type='block'`, nil)
+	expect(t, err, nil)
+	ee.OnMatch(qm, ListenerFunc(func(e Event) {}))
+
+	err = ee.Close(context.Background())
+	expect(t, err, nil)
+
+	l := 0
+	ee.matcherSubs.Range(func(_, _ interface{}) bool {
+		l++
+		return true
+	})
+	expect(t, l, 0)
+}
+
+func TestOnContext(t *testing.T) {
+	ee := New(0)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	list := ListenerFunc(func(event Event) {})
+	ee.OnContext(ctx, "test", list)
+	expect(t, len(ee.Listeners("test")), 1)
+
+	cancel()
+	for i := 0; i < 100 && len(ee.Listeners("test")) != 0; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	expect(t, len(ee.Listeners("test")), 0)
+}
+
+func TestEmitterClose(t *testing.T) {
+	ee := New(1)
+	ee.On("test", ListenerFunc(func(event Event) {}))
+	ee.On("other", ListenerFunc(func(event Event) {}))
+
+	err := ee.Close(context.Background())
+	expect(t, err, nil)
+	expect(t, len(ee.Topics()), 0)
+}
+
+func TestEmitterCloseDeadlineExceeded(t *testing.T) {
+	ee := New(1)
+	ee.On("test", ListenerFunc(func(event Event) {
+		time.Sleep(50 * time.Millisecond)
+	}))
+	<-ee.Emit(NewEvent("test", "elem"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	time.Sleep(time.Millisecond)
+
+	err := ee.Close(ctx)
+	expect(t, err, context.DeadlineExceeded)
+}
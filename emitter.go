@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"path"
 	"sync"
+	"sync/atomic"
 )
 
 // Flag used to describe what behavior
@@ -37,23 +38,67 @@ func Void(e Event) { e.SetFlag(e.Flag() | FlagVoid) }
 // Skip middleware sets FlagSkip flag for an event
 func Skip(e Event) { e.SetFlag(e.Flag() | FlagSkip) }
 
+// Option configures an Emitter created by New.
+type Option func(*Emitter)
+
+// WithOrderedDispatch makes every listener observe the events emitted for
+// its topic in the exact order Emit was called, by delivering them on the
+// calling goroutine instead of spawning one goroutine per emission. Slow
+// listeners still apply backpressure to Emit unless registered with Skip.
+func WithOrderedDispatch() Option {
+	return func(e *Emitter) {
+		e.ordered = true
+	}
+}
+
 // New returns just created Emitter struct. Capacity argument
 // will be used to create channels with given capacity
-func New(capacity uint) *Emitter {
-	return &Emitter{
-		Cap:         capacity,
-		listMans:    &sync.Map{},
-		middlewares: &sync.Map{},
+func New(capacity uint, opts ...Option) *Emitter {
+	e := &Emitter{
+		Cap:          capacity,
+		listMans:     &sync.Map{},
+		middlewares:  &sync.Map{},
+		matcherSubs:  &sync.Map{},
+		matcherMW:    &sync.Map{},
+		interceptors: &sync.Map{},
 	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
 }
 
 // Emitter is a struct that allows to emit, receive
 // event, close receiver channel, get info
 // about topics and listMans
 type Emitter struct {
-	Cap         uint
-	listMans    *sync.Map // sync.Map(string, sync.Map(string ptr addr, *listenerManager)
-	middlewares *sync.Map // sync.Map(string, []func(Event))
+	Cap            uint
+	listMans       *sync.Map // sync.Map(string, sync.Map(string ptr addr, *listenerManager)
+	middlewares    *sync.Map // sync.Map(string, []func(Event))
+	matcherSubs    *sync.Map // sync.Map(string ptr addr, *matcherSubscription), see OnMatch
+	matcherMW      *sync.Map // sync.Map(Matcher, []func(Event)), see UseMatch
+	interceptors   *sync.Map // sync.Map(uint64, func(Event)), see Intercept
+	interceptorSeq uint64
+	ordered        bool
+}
+
+// Intercept registers fn to run once for every event passed to Emit or
+// EmitContext, before any topic matching happens, regardless of whether a
+// listener is currently registered for that event's topic. It exists for
+// consumers like EventCache that need to observe every emission rather
+// than only the ones matching a particular pattern. Call the returned func
+// to unregister fn.
+func (e *Emitter) Intercept(fn func(Event)) (remove func()) {
+	id := atomic.AddUint64(&e.interceptorSeq, 1)
+	e.interceptors.Store(id, fn)
+	return func() { e.interceptors.Delete(id) }
+}
+
+func (e *Emitter) runInterceptors(event Event) {
+	e.interceptors.Range(func(_, fnRaw interface{}) bool {
+		fnRaw.(func(Event))(event)
+		return true
+	})
 }
 
 // Use registers middlewares for the pattern.
@@ -145,6 +190,8 @@ func (e *Emitter) Topics() []string {
 func (e *Emitter) Emit(event Event) chan struct{} {
 	done := make(chan struct{}, 1)
 
+	e.runInterceptors(event)
+
 	match, _ := e.matched(event.Topic())
 
 	var wg sync.WaitGroup
@@ -162,6 +209,13 @@ func (e *Emitter) Emit(event Event) chan struct{} {
 				if (evn.Flag() | FlagVoid) == evn.Flag() {
 					return true
 				}
+				if e.ordered {
+					_, remove, _ := pushEvent(done, listMan.ch, evn)
+					if remove {
+						e.Off(event.Topic(), listMan.listener)
+					}
+					return true
+				}
 				wg.Add(1)
 				haveToWait = true
 				go func(lm *listenerManager, event Event) {
@@ -176,6 +230,37 @@ func (e *Emitter) Emit(event Event) chan struct{} {
 			return true
 		})
 	}
+
+	e.matcherSubs.Range(func(_, subRaw interface{}) bool {
+		sub := subRaw.(*matcherSubscription)
+		if !sub.matcher.Match(event.Topic(), event) {
+			return true
+		}
+		evn := event.Clone()
+		applyMiddlewares(evn, e.getMatcherMiddlewares(sub.matcher))
+		applyMiddlewares(evn, sub.lm.middlewares)
+		if (evn.Flag() | FlagVoid) == evn.Flag() {
+			return true
+		}
+		if e.ordered {
+			_, remove, _ := pushEvent(done, sub.lm.ch, evn)
+			if remove {
+				e.OffMatch(sub.matcher, sub.lm.listener)
+			}
+			return true
+		}
+		wg.Add(1)
+		haveToWait = true
+		go func(lm *listenerManager, matcher Matcher, event Event) {
+			_, remove, _ := pushEvent(done, lm.ch, event)
+			if remove {
+				defer e.OffMatch(matcher, lm.listener)
+			}
+			wg.Done()
+		}(sub.lm, sub.matcher, evn)
+		return true
+	})
+
 	if haveToWait {
 		go func(done chan struct{}) {
 			wg.Wait()
@@ -229,6 +314,14 @@ func (e *Emitter) getMiddlewares(topic string) []func(Event) {
 	return acc
 }
 
+func (e *Emitter) getMatcherMiddlewares(matcher Matcher) []func(Event) {
+	middlewaresRaw, ok := e.matcherMW.Load(matcher)
+	if !ok {
+		return nil
+	}
+	return middlewaresRaw.([]func(Event))
+}
+
 func applyMiddlewares(e Event, fns []func(Event)) {
 	for i := range fns {
 		fns[i](e)
@@ -0,0 +1,131 @@
+package emitter
+
+import (
+	"path"
+	"sync"
+)
+
+// EventCache buffers events fired through it and lets newly-subscribed
+// listeners replay the ones still held in its ring buffer instead of
+// missing everything emitted before they subscribed.
+type EventCache struct {
+	e        *Emitter
+	capacity int
+	remove   func()
+
+	mu      sync.RWMutex
+	buffers map[string][]Event
+	pending []Event
+}
+
+// CacheOption configures an EventCache created by NewEventCache.
+type CacheOption func(*EventCache)
+
+// WithoutAutoArchive stops NewEventCache from registering itself on e via
+// Intercept, so only events given to FireEvent are archived. Use this when
+// a producer wants to opt every event into the cache explicitly instead of
+// having every e.Emit call picked up implicitly.
+func WithoutAutoArchive() CacheOption {
+	return func(c *EventCache) {
+		if c.remove != nil {
+			c.remove()
+			c.remove = nil
+		}
+	}
+}
+
+// NewEventCache wires a ring buffer of capacity events per topic on top of
+// e. By default it registers itself via e.Intercept so that any event
+// passed to e.Emit (or e.EmitContext) directly, not just the ones given to
+// FireEvent, is archived too - a producer doesn't need to know the cache
+// exists. Events given to FireEvent are additionally held back from e
+// until Flush is called, so several related events reach e's listeners
+// together. They are archived, and so served by Replay, as soon as
+// FireEvent is called, whether or not Flush follows; a pending batch
+// cannot be discarded.
+func NewEventCache(e *Emitter, capacity int, opts ...CacheOption) *EventCache {
+	c := &EventCache{
+		e:        e,
+		capacity: capacity,
+		buffers:  make(map[string][]Event),
+	}
+	c.remove = e.Intercept(c.observeEmit)
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// observeEmit is the func registered with e.Intercept: it archives every
+// event Emit sees, deduplicating against FireEvent's own archive call for
+// events that reach Emit again via Flush.
+func (c *EventCache) observeEmit(event Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.archive(event)
+}
+
+// FireEvent archives event in its topic's ring buffer and queues it for the
+// next Flush, instead of emitting it immediately.
+func (c *EventCache) FireEvent(event Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pending = append(c.pending, event)
+	c.archive(event)
+}
+
+// Flush emits every event queued by FireEvent since the last Flush, in the
+// order they were fired, and clears the pending batch.
+func (c *EventCache) Flush() {
+	c.mu.Lock()
+	pending := c.pending
+	c.pending = nil
+	c.mu.Unlock()
+
+	for _, event := range pending {
+		<-c.e.Emit(event)
+	}
+}
+
+// Replay immediately delivers the cached events whose topic matches topic
+// (which can be a pattern, as with Emitter.On) to listener. Events of one
+// topic arrive in the order they were archived; when topic matches several
+// cached topics, they are replayed topic by topic and the order between
+// topics is not defined.
+func (c *EventCache) Replay(topic string, listener Listener) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for cached, events := range c.buffers {
+		if !topicsMatch(topic, cached) {
+			continue
+		}
+		for _, event := range events {
+			listener.Observe(event.Clone())
+		}
+	}
+}
+
+// archive appends event to its topic's ring buffer unless it is already
+// there - a given event can reach here twice, once from FireEvent and once
+// more from observeEmit when Flush hands it back to e.Emit.
+func (c *EventCache) archive(event Event) {
+	events := c.buffers[event.Topic()]
+	for _, existing := range events {
+		if existing == event {
+			return
+		}
+	}
+	events = append(events, event)
+	if len(events) > c.capacity {
+		events = events[len(events)-c.capacity:]
+	}
+	c.buffers[event.Topic()] = events
+}
+
+func topicsMatch(pattern, topic string) bool {
+	if ok, _ := path.Match(pattern, topic); ok {
+		return true
+	}
+	ok, _ := path.Match(topic, pattern)
+	return ok
+}
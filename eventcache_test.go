@@ -0,0 +1,106 @@
+package emitter
+
+import "testing"
+
+func TestEventCacheReplay(t *testing.T) {
+	ee := New(1)
+	cache := NewEventCache(ee, 2)
+
+	cache.FireEvent(NewEvent("test", "a"))
+	cache.FireEvent(NewEvent("test", "b"))
+	cache.FireEvent(NewEvent("test", "c"))
+
+	var got []string
+	cache.Replay("test", ListenerFunc(func(e Event) {
+		got = append(got, e.Subject().(string))
+	}))
+
+	expect(t, len(got), 2)
+	expect(t, got[0], "b")
+	expect(t, got[1], "c")
+}
+
+func TestEventCacheFlush(t *testing.T) {
+	ee := New(1)
+	cache := NewEventCache(ee, 10)
+
+	done := make(chan struct{}, 1)
+	var got []string
+	ee.On("test", ListenerFunc(func(e Event) {
+		got = append(got, e.Subject().(string))
+		if len(got) == 2 {
+			done <- struct{}{}
+		}
+	}))
+
+	cache.FireEvent(NewEvent("test", "a"))
+	cache.FireEvent(NewEvent("test", "b"))
+	expect(t, len(got), 0)
+
+	cache.Flush()
+	<-done
+	expect(t, got[0], "a")
+	expect(t, got[1], "b")
+}
+
+func TestEventCacheArchivesDirectEmit(t *testing.T) {
+	ee := New(0)
+	cache := NewEventCache(ee, 10)
+
+	<-ee.Emit(NewEvent("direct", "v1"))
+	<-ee.Emit(NewEvent("direct", "v2"))
+
+	var got []string
+	cache.Replay("direct", ListenerFunc(func(e Event) {
+		got = append(got, e.Subject().(string))
+	}))
+
+	expect(t, len(got), 2)
+	expect(t, got[0], "v1")
+	expect(t, got[1], "v2")
+}
+
+func TestEventCacheWithoutAutoArchiveIgnoresDirectEmit(t *testing.T) {
+	ee := New(0)
+	cache := NewEventCache(ee, 10, WithoutAutoArchive())
+
+	<-ee.Emit(NewEvent("direct", "v1"))
+
+	var got []string
+	cache.Replay("direct", ListenerFunc(func(e Event) {
+		got = append(got, e.Subject().(string))
+	}))
+	expect(t, len(got), 0)
+}
+
+func TestEventCacheFlushDoesNotDuplicateArchivedEvents(t *testing.T) {
+	ee := New(1)
+	cache := NewEventCache(ee, 10)
+
+	cache.FireEvent(NewEvent("test", "a"))
+	cache.FireEvent(NewEvent("test", "b"))
+	cache.Flush()
+
+	var got []string
+	cache.Replay("test", ListenerFunc(func(e Event) {
+		got = append(got, e.Subject().(string))
+	}))
+	expect(t, len(got), 2)
+	expect(t, got[0], "a")
+	expect(t, got[1], "b")
+}
+
+func TestEventCacheReplayPattern(t *testing.T) {
+	ee := New(1)
+	cache := NewEventCache(ee, 5)
+
+	cache.FireEvent(NewEvent("user.created", "u1"))
+	cache.FireEvent(NewEvent("user.deleted", "u2"))
+
+	var got []string
+	cache.Replay("user.*", ListenerFunc(func(e Event) {
+		got = append(got, e.Subject().(string))
+	}))
+
+	expect(t, len(got), 2)
+}
@@ -0,0 +1,118 @@
+package filter
+
+import (
+	"testing"
+
+	"github.com/ArthurHlt/emitter"
+)
+
+func TestTypeFilterDropsMismatchedType(t *testing.T) {
+	ee := emitter.New(0)
+	chain := Chain{TypeFilter[string]()}
+
+	done := make(chan struct{}, 1)
+	ee.On("test", emitter.ListenerFunc(func(e emitter.Event) {
+		done <- struct{}{}
+	}), Middleware(chain))
+
+	<-ee.Emit(emitter.NewEvent("test", 42))
+	select {
+	case <-done:
+		t.Fatalf("listener should have been skipped for a non-string subject")
+	default:
+	}
+
+	<-ee.Emit(emitter.NewEvent("test", "elem"))
+	<-done
+}
+
+func TestSubjectFilter(t *testing.T) {
+	ee := emitter.New(0)
+	chain := Chain{SubjectFilter(func(n int) bool { return n > 100 })}
+
+	done := make(chan struct{}, 1)
+	ee.On("test", emitter.ListenerFunc(func(e emitter.Event) {
+		done <- struct{}{}
+	}), Middleware(chain))
+
+	<-ee.Emit(emitter.NewEvent("test", 1))
+	select {
+	case <-done:
+		t.Fatalf("listener should have been skipped for n<=100")
+	default:
+	}
+
+	<-ee.Emit(emitter.NewEvent("test", 150))
+	<-done
+}
+
+func TestMiddlewareDoesNotRewriteSubject(t *testing.T) {
+	upper := FilterFunc(func(e emitter.Event) (emitter.Event, bool) {
+		return emitter.NewEvent(e.Topic(), "rewritten"), true
+	})
+
+	var got string
+	done := make(chan struct{}, 1)
+	ee := emitter.New(0)
+	ee.On("test", emitter.ListenerFunc(func(e emitter.Event) {
+		got = e.Subject().(string)
+		done <- struct{}{}
+	}), Middleware(Chain{upper}))
+
+	<-ee.Emit(emitter.NewEvent("test", "original"))
+	<-done
+
+	if got != "original" {
+		t.Errorf("Middleware should leave the subject untouched - Expected %v - Got %v", "original", got)
+	}
+}
+
+func TestMiddlewareViaUseVoidsEveryListenerOnTopic(t *testing.T) {
+	ee := emitter.New(0)
+	chain := Chain{SubjectFilter(func(n int) bool { return n > 100 })}
+	ee.Use("test", Middleware(chain))
+
+	doneA := make(chan struct{}, 1)
+	ee.On("test", emitter.ListenerFunc(func(e emitter.Event) {
+		doneA <- struct{}{}
+	}))
+	doneB := make(chan struct{}, 1)
+	ee.On("test", emitter.ListenerFunc(func(e emitter.Event) {
+		doneB <- struct{}{}
+	}))
+
+	<-ee.Emit(emitter.NewEvent("test", 150))
+	<-doneA
+	<-doneB
+
+	<-ee.Emit(emitter.NewEvent("test", 1))
+	for _, done := range []chan struct{}{doneA, doneB} {
+		select {
+		case <-done:
+			t.Fatalf("a chain rejection registered via Use should void every listener on the topic")
+		default:
+		}
+	}
+}
+
+func TestWrapRewritesSubject(t *testing.T) {
+	upper := FilterFunc(func(e emitter.Event) (emitter.Event, bool) {
+		return emitter.NewEvent(e.Topic(), "rewritten"), true
+	})
+
+	done := make(chan struct{}, 1)
+	var got string
+	listener := Wrap(emitter.ListenerFunc(func(e emitter.Event) {
+		got = e.Subject().(string)
+		done <- struct{}{}
+	}), Chain{upper})
+
+	ee := emitter.New(0)
+	ee.On("test", listener)
+	<-ee.Emit(emitter.NewEvent("test", "original"))
+	<-done
+
+	if got != "rewritten" {
+		t.Errorf("Expected %v - Got %v", "rewritten", got)
+	}
+}
@@ -24,6 +24,7 @@ type listenerManager struct {
 	ch          chan Event
 	middlewares []func(Event)
 	listener    Listener
+	stopped     chan struct{}
 }
 
 func newListenerManager(capacity uint, listener Listener, middlewares ...func(Event)) *listenerManager {
@@ -31,6 +32,7 @@ func newListenerManager(capacity uint, listener Listener, middlewares ...func(Ev
 		ch:          make(chan Event, capacity),
 		middlewares: middlewares,
 		listener:    listener,
+		stopped:     make(chan struct{}),
 	}
 	go func() {
 		lm.observe()
@@ -39,6 +41,7 @@ func newListenerManager(capacity uint, listener Listener, middlewares ...func(Ev
 }
 
 func (lm *listenerManager) observe() {
+	defer close(lm.stopped)
 	for e := range lm.ch {
 		lm.listener.Observe(e)
 	}
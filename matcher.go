@@ -0,0 +1,253 @@
+package emitter
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Matcher lets a subscription predicate over the event itself, not just its
+// topic string. It is consumed by the separate OnMatch/UseMatch/OffMatch
+// methods rather than by On/Use/Off themselves: those three keep their
+// existing path.Match-only string signature unchanged, and Matcher
+// subscriptions live in their own registry (matcherSubs/matcherMW) instead.
+// RegexMatcher and QueryMatcher below are the two implementations this
+// package ships.
+type Matcher interface {
+	// Match reports whether event, emitted under topic, satisfies the
+	// matcher.
+	Match(topic string, event Event) bool
+}
+
+// RegexMatcher matches the event topic against a compiled regular
+// expression instead of a path.Match glob.
+type RegexMatcher struct {
+	re *regexp.Regexp
+}
+
+// NewRegexMatcher compiles expr and returns a Matcher that matches every
+// topic it matches.
+func NewRegexMatcher(expr string) (*RegexMatcher, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &RegexMatcher{re: re}, nil
+}
+
+// Match reports whether topic satisfies the regular expression.
+func (m *RegexMatcher) Match(topic string, _ Event) bool {
+	return m.re.MatchString(topic)
+}
+
+// QueryMatcher matches events whose Subject() satisfies a small
+// tag/attribute query language, e.g. `type='block' AND height>100`. Fields
+// are read from the Subject via reflection (exported struct fields or
+// map[string]any keys) unless an extractor is supplied to NewQueryMatcher.
+type QueryMatcher struct {
+	conds     []queryCond
+	extractor func(Event) map[string]any
+}
+
+// NewQueryMatcher parses query, a sequence of `field OP value` conditions
+// joined by `AND`, and returns a Matcher over Event.Subject(). extractor is
+// optional; when nil, Subject() is expected to be a struct or a
+// map[string]any and its fields are read directly.
+func NewQueryMatcher(query string, extractor func(Event) map[string]any) (*QueryMatcher, error) {
+	conds, err := parseQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	return &QueryMatcher{conds: conds, extractor: extractor}, nil
+}
+
+// Match reports whether event's fields satisfy every condition of the
+// query.
+func (m *QueryMatcher) Match(_ string, event Event) bool {
+	fields := m.fields(event)
+	for _, cond := range m.conds {
+		v, ok := fields[cond.field]
+		if !ok || !cond.eval(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *QueryMatcher) fields(event Event) map[string]any {
+	if m.extractor != nil {
+		return m.extractor(event)
+	}
+	return fieldsOf(event.Subject())
+}
+
+func fieldsOf(subject any) map[string]any {
+	acc := make(map[string]any)
+	if fields, ok := subject.(map[string]any); ok {
+		for k, v := range fields {
+			acc[k] = v
+		}
+		return acc
+	}
+	v := reflect.ValueOf(subject)
+	for v.Kind() == reflect.Ptr {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return acc
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		acc[field.Name] = v.Field(i).Interface()
+	}
+	return acc
+}
+
+type queryCond struct {
+	field string
+	op    string
+	value string
+}
+
+var queryOps = []string{">=", "<=", "!=", "=", ">", "<"}
+
+// andSep splits a query on a standalone "AND" token, so a field name like
+// Brand or Island is left intact instead of being cut wherever those
+// letters happen to appear.
+var andSep = regexp.MustCompile(`\s+AND\s+`)
+
+func parseQuery(query string) ([]queryCond, error) {
+	var conds []queryCond
+	for _, part := range andSep.Split(query, -1) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		cond, err := parseCond(part)
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, cond)
+	}
+	return conds, nil
+}
+
+func parseCond(part string) (queryCond, error) {
+	for _, op := range queryOps {
+		idx := strings.Index(part, op)
+		if idx < 0 {
+			continue
+		}
+		field := strings.TrimSpace(part[:idx])
+		value := strings.TrimSpace(part[idx+len(op):])
+		value = strings.Trim(value, `'"`)
+		return queryCond{field: field, op: op, value: value}, nil
+	}
+	return queryCond{}, fmt.Errorf("emitter: invalid query condition %q", part)
+}
+
+func (c queryCond) eval(v any) bool {
+	if c.op == "=" || c.op == "!=" {
+		eq := fmt.Sprint(v) == c.value
+		if c.op == "!=" {
+			return !eq
+		}
+		return eq
+	}
+	a, ok := toFloat(v)
+	if !ok {
+		return false
+	}
+	b, err := strconv.ParseFloat(c.value, 64)
+	if err != nil {
+		return false
+	}
+	switch c.op {
+	case ">":
+		return a > b
+	case "<":
+		return a < b
+	case ">=":
+		return a >= b
+	case "<=":
+		return a <= b
+	}
+	return false
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	default:
+		return 0, false
+	}
+}
+
+type matcherSubscription struct {
+	matcher Matcher
+	lm      *listenerManager
+}
+
+// OnMatch registers listener for every event, on any topic, that matcher
+// accepts via Match. Subscriptions made this way are tracked separately
+// from On's topic-keyed listMans, in e.matcherSubs.
+func (e *Emitter) OnMatch(matcher Matcher, listener Listener, middlewares ...func(Event)) {
+	lm := newListenerManager(e.Cap, listener, middlewares...)
+	e.matcherSubs.Store(fmt.Sprintf("%p", listener), &matcherSubscription{matcher: matcher, lm: lm})
+}
+
+// UseMatch registers middlewares for the OnMatch subscriptions registered
+// with this same matcher value; they run, before each subscription's own
+// middlewares, on every event such a subscription receives (see
+// getMatcherMiddlewares). Subscriptions made with a different matcher, even
+// one accepting the same events, and listeners registered with On are not
+// affected.
+func (e *Emitter) UseMatch(matcher Matcher, middlewares ...func(Event)) {
+	if len(middlewares) == 0 {
+		e.matcherMW.Delete(matcher)
+		return
+	}
+	e.matcherMW.Store(matcher, middlewares)
+}
+
+// OffMatch unsubscribes listeners registered via OnMatch with matcher. With
+// no listeners given, every subscription registered for matcher is removed.
+func (e *Emitter) OffMatch(matcher Matcher, listeners ...Listener) {
+	e.matcherSubs.Range(func(key, subRaw interface{}) bool {
+		sub := subRaw.(*matcherSubscription)
+		if sub.matcher != matcher {
+			return true
+		}
+		if len(listeners) == 0 {
+			close(sub.lm.ch)
+			e.matcherSubs.Delete(key)
+			return true
+		}
+		for _, l := range listeners {
+			if sub.lm.listener == l {
+				close(sub.lm.ch)
+				e.matcherSubs.Delete(key)
+			}
+		}
+		return true
+	})
+}
@@ -0,0 +1,127 @@
+package emitter
+
+import (
+	"testing"
+)
+
+type blockEvent struct {
+	Type   string
+	Height int
+}
+
+type brandEvent struct {
+	Brand string
+}
+
+func TestParseQueryDoesNotSplitFieldNamesContainingAND(t *testing.T) {
+	qm, err := NewQueryMatcher(`Brand='island'`, nil)
+	expect(t, err, nil)
+	expect(t, qm.Match("", NewEvent("chain", brandEvent{Brand: "island"})), true)
+	expect(t, qm.Match("", NewEvent("chain", brandEvent{Brand: "other"})), false)
+}
+
+func TestOnMatchRegex(t *testing.T) {
+	ee := New(0)
+	re, err := NewRegexMatcher(`^user\.\w+$`)
+	expect(t, err, nil)
+
+	done := make(chan struct{}, 1)
+	ee.OnMatch(re, ListenerFunc(func(e Event) {
+		expect(t, e.Topic(), "user.created")
+		done <- struct{}{}
+	}))
+
+	<-ee.Emit(NewEvent("user.created", "u1"))
+	<-done
+
+	ee.OffMatch(re)
+	l := 0
+	ee.matcherSubs.Range(func(_, _ interface{}) bool {
+		l++
+		return true
+	})
+	expect(t, l, 0)
+}
+
+func TestOnMatchQuery(t *testing.T) {
+	ee := New(0)
+	qm, err := NewQueryMatcher(`Type='block' AND Height>100`, nil)
+	expect(t, err, nil)
+
+	done := make(chan struct{}, 1)
+	ee.OnMatch(qm, ListenerFunc(func(e Event) {
+		done <- struct{}{}
+	}))
+
+	<-ee.Emit(NewEvent("chain", blockEvent{Type: "block", Height: 50}))
+	select {
+	case <-done:
+		t.Fatalf("listener should not have matched Height=50")
+	default:
+	}
+
+	<-ee.Emit(NewEvent("chain", blockEvent{Type: "block", Height: 150}))
+	<-done
+}
+
+func TestUseMatchVoidDoesNotLeakAcrossOtherMatcherSubscriptions(t *testing.T) {
+	// sync.Map.Range order is unspecified, so the voided subscription could
+	// happen to run after the plain one; repeat with fresh subscriptions to
+	// make sure the bug isn't masked by a lucky iteration order.
+	for i := 0; i < 20; i++ {
+		ee := New(0)
+
+		// Two distinct matcher instances compiled from the same query, so
+		// both subscriptions match the same event but only one is wired to
+		// Void.
+		voidedMatcher, err := NewQueryMatcher(`Type='block'`, nil)
+		expect(t, err, nil)
+		plainMatcher, err := NewQueryMatcher(`Type='block'`, nil)
+		expect(t, err, nil)
+		ee.UseMatch(voidedMatcher, Void)
+
+		voided := make(chan struct{}, 1)
+		ee.OnMatch(voidedMatcher, ListenerFunc(func(e Event) {
+			voided <- struct{}{}
+		}))
+
+		other := make(chan struct{}, 1)
+		ee.OnMatch(plainMatcher, ListenerFunc(func(e Event) {
+			other <- struct{}{}
+		}))
+
+		<-ee.Emit(NewEvent("chain", blockEvent{Type: "block", Height: 1}))
+
+		select {
+		case <-voided:
+			t.Fatalf("voidedMatcher's subscription should have been voided by UseMatch")
+		default:
+		}
+		<-other
+	}
+}
+
+func TestUseMatchVoidsOnlyMatchingListener(t *testing.T) {
+	ee := New(0)
+	qm, err := NewQueryMatcher(`Type='block'`, nil)
+	expect(t, err, nil)
+	ee.UseMatch(qm, Void)
+
+	matched := make(chan struct{}, 1)
+	ee.OnMatch(qm, ListenerFunc(func(e Event) {
+		matched <- struct{}{}
+	}))
+
+	done := make(chan struct{}, 1)
+	ee.On("chain", ListenerFunc(func(e Event) {
+		done <- struct{}{}
+	}))
+
+	<-ee.Emit(NewEvent("chain", blockEvent{Type: "block", Height: 1}))
+	<-done
+	select {
+	case <-matched:
+		t.Fatalf("matcher-registered listener should have been voided")
+	default:
+	}
+}
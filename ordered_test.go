@@ -0,0 +1,63 @@
+package emitter
+
+import (
+	"context"
+	"testing"
+)
+
+func TestOrderedDispatch(t *testing.T) {
+	ee := New(0, WithOrderedDispatch())
+
+	var got []int
+	done := make(chan struct{}, 1)
+	ee.On("test", ListenerFunc(func(event Event) {
+		got = append(got, event.Subject().(int))
+		if len(got) == 3 {
+			done <- struct{}{}
+		}
+	}))
+
+	<-ee.Emit(NewEvent("test", 1))
+	<-ee.Emit(NewEvent("test", 2))
+	<-ee.Emit(NewEvent("test", 3))
+	<-done
+
+	expect(t, got[0], 1)
+	expect(t, got[1], 2)
+	expect(t, got[2], 3)
+}
+
+func TestOrderedDispatchViaEmitContext(t *testing.T) {
+	ee := New(0, WithOrderedDispatch())
+	ctx := context.Background()
+
+	var got []int
+	done := make(chan struct{}, 1)
+	ee.On("test", ListenerFunc(func(event Event) {
+		got = append(got, event.Subject().(int))
+		if len(got) == 3 {
+			done <- struct{}{}
+		}
+	}))
+
+	<-ee.EmitContext(ctx, NewEvent("test", 1))
+	<-ee.EmitContext(ctx, NewEvent("test", 2))
+	<-ee.EmitContext(ctx, NewEvent("test", 3))
+	<-done
+
+	expect(t, got[0], 1)
+	expect(t, got[1], 2)
+	expect(t, got[2], 3)
+}
+
+func TestOrderedDispatchSkip(t *testing.T) {
+	ee := New(0, WithOrderedDispatch())
+
+	ch := make(chan struct{})
+	ee.On("test", ListenerFunc(func(event Event) {
+		ch <- struct{}{}
+	}), Skip)
+
+	// unbuffered channel with no reader yet: a skipped send must not block.
+	<-ee.Emit(NewEvent("test", 1))
+}
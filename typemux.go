@@ -0,0 +1,135 @@
+package emitter
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+)
+
+// ErrMuxClosed is returned by TypeMux.Emit and OnType/SubscribeType once
+// Close has been called on the mux.
+var ErrMuxClosed = errors.New("emitter: type mux is closed")
+
+// TypeMux dispatches events to subscribers registered by Go type rather
+// than by string topic: an event is only ever handed to listeners
+// registered, via OnType, for the exact reflect.Type of its Subject().
+type TypeMux struct {
+	Cap uint
+
+	mu       sync.RWMutex
+	managers map[reflect.Type][]*listenerManager
+	closed   bool
+}
+
+// NewTypeMux returns a TypeMux ready to dispatch events by subject type.
+// Capacity is used for each subscriber's channel, mirroring New.
+func NewTypeMux(capacity uint) *TypeMux {
+	return &TypeMux{
+		Cap:      capacity,
+		managers: make(map[reflect.Type][]*listenerManager),
+	}
+}
+
+// OnType registers listener for every event whose Subject() is of type T.
+func OnType[T any](m *TypeMux, listener ListenerFuncOf[T], middlewares ...func(Event)) error {
+	return SubscribeType[T](m, listener, middlewares...)
+}
+
+// SubscribeType registers listener for every event whose Subject() is of
+// type T. It is equivalent to OnType and named to mirror the package's
+// EventOf[T]/ListenerFuncOf[T] generic helpers.
+func SubscribeType[T any](m *TypeMux, listener ListenerFuncOf[T], middlewares ...func(Event)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return ErrMuxClosed
+	}
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	// An event whose Subject() is a T but which is not an *EventOf[T] (e.g. a
+	// *BasicEvent) is rewrapped so that listener still receives it.
+	wrapped := ListenerFunc(func(e Event) {
+		typed, ok := e.(*EventOf[T])
+		if !ok {
+			subject, isT := e.Subject().(T)
+			if !isT {
+				return
+			}
+			typed = &EventOf[T]{topic: e.Topic(), flag: e.Flag(), subject: subject}
+		}
+		listener(typed)
+	})
+	lm := newListenerManager(m.Cap, wrapped, middlewares...)
+	m.managers[t] = append(m.managers[t], lm)
+	return nil
+}
+
+// Emit dispatches event to every listener registered, via OnType, for the
+// Go type of event.Subject(). Delivery to each listener runs on its own
+// goroutine through the same pushEvent/send path as Emitter.Emit, so a
+// listener that isn't draining its channel blocks only that goroutine, not
+// Emit itself, and never holds the mux's lock. FlagSkip and FlagOnce are
+// honored exactly as they are by Emitter. It returns ErrMuxClosed once
+// Close has been called.
+func (m *TypeMux) Emit(event Event) error {
+	m.mu.RLock()
+	if m.closed {
+		m.mu.RUnlock()
+		return ErrMuxClosed
+	}
+	t := reflect.TypeOf(event.Subject())
+	lms := append([]*listenerManager(nil), m.managers[t]...)
+	m.mu.RUnlock()
+
+	done := make(chan struct{})
+	for _, lm := range lms {
+		evn := event.Clone()
+		applyMiddlewares(evn, lm.middlewares)
+		if (evn.Flag() | FlagVoid) == evn.Flag() {
+			continue
+		}
+		go func(lm *listenerManager, evn Event) {
+			_, remove, _ := pushEvent(done, lm.ch, evn)
+			if remove {
+				m.removeListener(t, lm)
+			}
+		}(lm, evn)
+	}
+	return nil
+}
+
+// removeListener drops lm from the managers registered for t and closes
+// its channel, mirroring how Emitter.Off removes a listener once pushEvent
+// reports it should go (e.g. after a FlagOnce delivery).
+func (m *TypeMux) removeListener(t reflect.Type, lm *listenerManager) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return
+	}
+	lms := m.managers[t]
+	for i, candidate := range lms {
+		if candidate == lm {
+			m.managers[t] = append(lms[:i:i], lms[i+1:]...)
+			close(lm.ch)
+			return
+		}
+	}
+}
+
+// Close shuts down the mux: every registered listener's channel is closed
+// and subsequent calls to Emit or OnType/SubscribeType return ErrMuxClosed.
+func (m *TypeMux) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.closed {
+		return ErrMuxClosed
+	}
+	m.closed = true
+	for _, lms := range m.managers {
+		for _, lm := range lms {
+			close(lm.ch)
+		}
+	}
+	m.managers = nil
+	return nil
+}
@@ -0,0 +1,89 @@
+package emitter
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTypeMuxBasic(t *testing.T) {
+	m := NewTypeMux(1)
+	done := make(chan struct{}, 1)
+	err := OnType[string](m, func(e *EventOf[string]) {
+		expect(t, e.TypedSubject(), "elem")
+		done <- struct{}{}
+	})
+	expect(t, err, nil)
+
+	err = m.Emit(NewEventOf[string]("test", "elem"))
+	expect(t, err, nil)
+	<-done
+}
+
+func TestTypeMuxOnlyMatchingType(t *testing.T) {
+	m := NewTypeMux(1)
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	err := SubscribeType[int](m, func(e *EventOf[int]) {
+		defer wg.Done()
+		expect(t, e.TypedSubject(), 42)
+	})
+	expect(t, err, nil)
+
+	expect(t, m.Emit(NewEventOf[string]("test", "elem")), nil)
+	expect(t, m.Emit(NewEventOf[int]("test", 42)), nil)
+	wg.Wait()
+}
+
+func TestTypeMuxEmitDoesNotBlockOnFullBuffer(t *testing.T) {
+	m := NewTypeMux(1)
+	block := make(chan struct{})
+	err := OnType[int](m, func(e *EventOf[int]) {
+		<-block
+	})
+	expect(t, err, nil)
+
+	done := make(chan struct{})
+	go func() {
+		expect(t, m.Emit(NewEventOf[int]("test", 1)), nil)
+		expect(t, m.Emit(NewEventOf[int]("test", 2)), nil)
+		expect(t, m.Emit(NewEventOf[int]("test", 3)), nil)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Emit blocked with a full listener buffer instead of returning")
+	}
+	close(block)
+}
+
+func TestTypeMuxOnceListenerIsRemovedAfterDelivery(t *testing.T) {
+	m := NewTypeMux(1)
+	done := make(chan struct{}, 1)
+	err := OnType[string](m, func(e *EventOf[string]) {
+		done <- struct{}{}
+	}, Once)
+	expect(t, err, nil)
+
+	expect(t, m.Emit(NewEventOf[string]("test", "a")), nil)
+	<-done
+
+	expect(t, m.Emit(NewEventOf[string]("test", "b")), nil)
+	select {
+	case <-done:
+		t.Fatal("Once listener should have been removed after its first delivery")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestTypeMuxClose(t *testing.T) {
+	m := NewTypeMux(0)
+	expect(t, m.Close(), nil)
+	expect(t, m.Close(), ErrMuxClosed)
+	expect(t, m.Emit(NewEventOf[string]("test", "elem")), ErrMuxClosed)
+
+	err := OnType[string](m, func(e *EventOf[string]) {})
+	expect(t, err, ErrMuxClosed)
+}